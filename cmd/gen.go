@@ -4,10 +4,11 @@ import (
 	"fmt"
 	"github.com/gospodinzerkalo/gocodegen/parser"
 	"os"
+	"path/filepath"
 )
 
 func main() {
-	p, err := parser.NewParser(os.Getenv("GOFILE"))
+	p, err := parser.NewParser(filepath.Dir(os.Getenv("GOFILE")), parser.Config{})
 	if err != nil {
 		fmt.Println(err)
 		return
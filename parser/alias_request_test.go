@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"os"
+	"testing"
+)
+
+// TestAliasedRequestType guards against regressing to the unguarded
+// st.Type.(*ast.StructType) assertion: a Request type declared as an alias
+// to a struct ("type GetUserRequest = innerRequest") must resolve via
+// go/types instead of panicking.
+func TestAliasedRequestType(t *testing.T) {
+	p, err := NewParser("testdata/aliasrequest", Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	if err := os.Chdir(outDir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	if err := p.Parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	entity := p.EntitiesByName["GetUser"]
+	if entity == nil || len(entity.Parameters) != 1 || entity.Parameters[0].Field != "ID" {
+		t.Fatalf("expected aliased request's field to resolve, got %+v", entity)
+	}
+}
+
+// TestNonStructRequestTypeReturnsError guards the other half of the same
+// fix: a Request type that isn't a struct and isn't an alias to one (e.g.
+// "type GetUserRequest string") must return an error, not panic.
+func TestNonStructRequestTypeReturnsError(t *testing.T) {
+	p, err := NewParser("testdata/badrequest", Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Parse(); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+}
@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"io/ioutil"
+	"text/template"
+)
+
+// builtinBackendNames are the backends templates/ ships alongside
+// logging-service.go.tmpl: an HTTP client, a gRPC server stub, and a
+// Markdown API reference, all rendered from the same parsed Entities.
+var builtinBackendNames = []string{"http-client", "grpc-stub", "markdown-docs"}
+
+// Backend turns a Parser's parsed Entities into an output file. The built-in
+// backends below all render a text/template against the Parser, but the
+// interface leaves room for backends that don't go through text/template at
+// all (e.g. a backend that shells out to protoc).
+type Backend interface {
+	Generate(p *Parser, name string) error
+}
+
+// templateBackend is the Backend used by RegisterBackend: it renders tmpl
+// against the Parser and writes the result to "<name>" plus backendExt(name),
+// passing ".go" output through goimports the same way generateCode always has.
+type templateBackend struct {
+	tmpl *template.Template
+}
+
+func (b *templateBackend) Generate(p *Parser, name string) error {
+	return p.generateCode(b.tmpl, name+backendExt(name))
+}
+
+// backendExt picks the output extension for one of the built-in backend
+// names; anything generating Go source (logging-service, http-client,
+// grpc-stub, and custom backends) gets ".go" run through goimports, while
+// markdown-docs is written out verbatim.
+func backendExt(name string) string {
+	if name == "markdown-docs" {
+		return ".md"
+	}
+	return ".go"
+}
+
+// RegisterBackend makes tmpl available under name, so that a subsequent call
+// to Parse also renders it (GenerateKitService does not render registered
+// backends; it renders only the templates/kit set). "logging-service" is
+// registered by NewParser itself; callers add "http-client", "grpc-stub",
+// "markdown-docs", or any custom name of their own.
+func (p *Parser) RegisterBackend(name string, tmpl *template.Template) {
+	p.backends[name] = &templateBackend{tmpl: tmpl}
+}
+
+// registerBuiltinTargets loads and registers every backend under
+// templatesDir ("logging-service" plus builtinBackendNames), the multi-target
+// generation NewParserFromOpenAPI fans out to.
+func (p *Parser) registerBuiltinTargets(templatesDir string) error {
+	for _, name := range append([]string{"logging-service"}, builtinBackendNames...) {
+		raw, err := ioutil.ReadFile(templatesDir + "/" + name + backendExt(name) + ".tmpl")
+		if err != nil {
+			return err
+		}
+		p.RegisterBackend(name, template.Must(template.New(name).Parse(string(raw))))
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"go/ast"
+	"go/types"
+	"regexp"
+)
+
+// Config controls how Parser discovers endpoints and Request/Response
+// types, so a project that doesn't name its receiver "api" or suffix its
+// DTOs "Request"/"Response" can still use gocodegen. The zero Config falls
+// back to that original convention.
+type Config struct {
+	// ReceiverNames lists the exported-method receiver type names that
+	// count as endpoints, e.g. []string{"api"}. Ignored if ReceiverPattern
+	// or ServiceInterface is set.
+	ReceiverNames []string
+	// ReceiverPattern, if set, takes priority over ReceiverNames and
+	// matches the receiver type name by regex.
+	ReceiverPattern *regexp.Regexp
+	// ServiceInterface, if set, takes priority over both of the above:
+	// every method declared on the named interface is treated as an
+	// endpoint, regardless of which receiver implements it.
+	ServiceInterface string
+	// EndpointFilter, if set, takes priority over everything above and
+	// decides per *ast.FuncDecl whether it's an endpoint.
+	EndpointFilter func(*ast.FuncDecl) bool
+	// RequestSuffix/ResponseSuffix default to "Request"/"Response".
+	RequestSuffix  string
+	ResponseSuffix string
+}
+
+func (c Config) withDefaults() Config {
+	if c.RequestSuffix == "" {
+		c.RequestSuffix = "Request"
+	}
+	if c.ResponseSuffix == "" {
+		c.ResponseSuffix = "Response"
+	}
+	if c.ReceiverPattern == nil && c.ServiceInterface == "" && c.EndpointFilter == nil && len(c.ReceiverNames) == 0 {
+		c.ReceiverNames = []string{"api"}
+	}
+	return c
+}
+
+// resolveServiceInterface resolves Config.ServiceInterface to the
+// *types.Interface it names, via the go/types info NewParser loaded. It
+// returns nil if ServiceInterface isn't set or can't be resolved to an
+// interface, which parseFunction treats as "fall through to receiver-based
+// discovery".
+func resolveServiceInterface(pkgTypes *types.Package, name string) *types.Interface {
+	if name == "" || pkgTypes == nil {
+		return nil
+	}
+	obj := pkgTypes.Scope().Lookup(name)
+	if obj == nil {
+		return nil
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil
+	}
+	iface, ok := named.Underlying().(*types.Interface)
+	if !ok {
+		return nil
+	}
+	return iface
+}
+
+// serviceInterfaceMethodNames returns the set of method names declared on
+// iface, used by parseFunction as a cheap pre-filter before the full
+// types.Implements check.
+func serviceInterfaceMethodNames(iface *types.Interface) map[string]bool {
+	if iface == nil {
+		return nil
+	}
+	methods := make(map[string]bool, iface.NumMethods())
+	for i := 0; i < iface.NumMethods(); i++ {
+		methods[iface.Method(i).Name()] = true
+	}
+	return methods
+}
@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"os"
+	"testing"
+)
+
+// TestNewParserResolvesAcrossFiles exercises the go/packages-based loading
+// NewParser uses: GetUserRequest/GetUserResponse live in a different file
+// than the api receiver that references them, and GetUserRequest embeds
+// Pagination, whose fields must be flattened into GetUser's Parameters.
+func TestNewParserResolvesAcrossFiles(t *testing.T) {
+	p, err := NewParser("testdata/crossfile", Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	if err := os.Chdir(outDir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	if err := p.Parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	entity := p.EntitiesByName["GetUser"]
+	if entity == nil {
+		t.Fatal("expected GetUser endpoint to be discovered")
+	}
+
+	if entity.Response == nil || entity.Response.Type != "Object" {
+		t.Fatalf("expected GetUser to have an Object response resolved from the other file, got %+v", entity.Response)
+	}
+	if len(entity.Response.Fields) != 1 || entity.Response.Fields[0].Field != "Name" {
+		t.Fatalf("expected GetUserResponse.Name to resolve, got %+v", entity.Response.Fields)
+	}
+
+	wantParams := map[string]string{"Page": "int", "PageSize": "int", "ID": "string"}
+	if len(entity.Parameters) != len(wantParams) {
+		t.Fatalf("expected embedded Pagination fields to be flattened alongside ID, got %+v", entity.Parameters)
+	}
+	for _, param := range entity.Parameters {
+		wantType, ok := wantParams[param.Field]
+		if !ok {
+			t.Fatalf("unexpected parameter %q", param.Field)
+		}
+		if param.Type != wantType {
+			t.Fatalf("parameter %q: got type %q, want %q", param.Field, param.Type, wantType)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+)
+
+// collectParameters walks st's fields, expanding any anonymous (embedded)
+// field into its own fields instead of emitting one Parameter for the
+// embedded struct itself. Resolving an embedded field's members needs the
+// go/types info NewParser loaded; callers built from a non-Go source (proto,
+// OpenAPI) never hit this path since they don't produce *ast.StructType.
+func (p *Parser) collectParameters(st *ast.StructType) ([]Parameter, error) {
+	var params []Parameter
+	for _, field := range st.Fields.List {
+		if field.Names == nil {
+			embedded, err := p.expandEmbeddedField(field)
+			if err != nil {
+				return nil, err
+			}
+			params = append(params, embedded...)
+			continue
+		}
+
+		t, err := p.mapFieldType(field.Type)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, Parameter{
+			Field:       field.Names[0].Name,
+			Description: field.Doc.Text(),
+			Tag:         parseTag(field.Tag.Value),
+			Type:        t,
+		})
+	}
+	return params, nil
+}
+
+// expandEmbeddedField resolves the struct type behind an embedded field
+// (following named types and pointers) and flattens its fields into
+// Parameters. It returns nil, nil - not an error - when type info isn't
+// available or the embedded type isn't a struct, leaving the field to be
+// silently dropped the same way it would be if it had no go/types info at all.
+func (p *Parser) expandEmbeddedField(field *ast.Field) ([]Parameter, error) {
+	st, ok := p.resolveNamedStruct(field.Type)
+	if !ok {
+		return nil, nil
+	}
+	return typesStructParameters(st), nil
+}
+
+// resolveNamedStruct resolves expr - anything other than a literal
+// *ast.StructType, e.g. an embedded field's named type or a Request type
+// declared as an alias ("type GetUserRequest = innerRequest") - through the
+// go/types info NewParser loaded, to the *types.Struct behind it (following
+// named types and pointers). ok is false when type info isn't available or
+// expr doesn't resolve to a struct.
+func (p *Parser) resolveNamedStruct(expr ast.Expr) (*types.Struct, bool) {
+	if p.info == nil {
+		return nil, false
+	}
+	tv, ok := p.info.Types[expr]
+	if !ok {
+		return nil, false
+	}
+	return underlyingStruct(tv.Type)
+}
+
+func underlyingStruct(t types.Type) (*types.Struct, bool) {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	return st, ok
+}
+
+// typesStructParameters turns a resolved *types.Struct's fields into
+// Parameters, the same shape collectParameters produces from an
+// *ast.StructType's fields - minus field doc comments, which go/types
+// doesn't carry.
+func typesStructParameters(st *types.Struct) []Parameter {
+	params := make([]Parameter, 0, st.NumFields())
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		params = append(params, Parameter{
+			Field: f.Name(),
+			Type:  typesTypeToString(f.Type()),
+			Tag:   reflect.StructTag(st.Tag(i)).Get("json"),
+		})
+	}
+	return params
+}
+
+// typesTypeToString maps a resolved go/types.Type to a type string, for the
+// embedded fields that only go/types - not the AST - gives us: basic types by
+// name, slices and arrays as "[]T", maps as "map[K]V", and named types as
+// "pkg.Name" (including types of the package being parsed). Mappings added
+// via RegisterTypeMapping or RegisterTypeMapper are not applied here.
+func typesTypeToString(t types.Type) string {
+	switch x := t.(type) {
+	case *types.Basic:
+		return x.Name()
+	case *types.Pointer:
+		return typesTypeToString(x.Elem())
+	case *types.Slice:
+		return "[]" + typesTypeToString(x.Elem())
+	case *types.Array:
+		return "[]" + typesTypeToString(x.Elem())
+	case *types.Map:
+		return fmt.Sprintf("map[%s]%s", typesTypeToString(x.Key()), typesTypeToString(x.Elem()))
+	case *types.Named:
+		obj := x.Obj()
+		if pkg := obj.Pkg(); pkg != nil {
+			return pkg.Name() + "." + obj.Name()
+		}
+		return obj.Name()
+	default:
+		return "Object"
+	}
+}
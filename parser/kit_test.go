@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestGenerateKitServiceProducesValidGo parses a fixture package and asserts
+// all four go-kit layers GenerateKitService renders (service, endpoint,
+// transport, middleware) are valid Go - this template set shipped with no
+// automated check at all before.
+func TestGenerateKitServiceProducesValidGo(t *testing.T) {
+	p, err := NewParser("testdata/crossfile", Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parseOutDir := t.TempDir()
+	if err := os.Chdir(parseOutDir); err != nil {
+		t.Fatal(err)
+	}
+	err = p.Parse()
+	if chdirErr := os.Chdir(wd); chdirErr != nil {
+		t.Fatal(chdirErr)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kitOutDir := t.TempDir()
+	if err := p.GenerateKitService(kitOutDir); err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	for _, name := range []string{"service", "endpoint", "transport", "middleware"} {
+		fn := filepath.Join(kitOutDir, name+".go")
+		if _, err := parser.ParseFile(fset, fn, nil, 0); err != nil {
+			t.Fatalf("%s.go is not valid Go: %v", name, err)
+		}
+	}
+}
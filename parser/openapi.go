@@ -0,0 +1,253 @@
+package parser
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// openAPIDocument is the subset of an OpenAPI 3.0 / Swagger 2.0 document
+// that NewParserFromOpenAPI needs. Both JSON and YAML encode it the same
+// way, so the same struct tags cover both.
+type openAPIDocument struct {
+	Paths      map[string]map[string]openAPIOperation `json:"paths" yaml:"paths"`
+	Components struct {
+		Schemas map[string]openAPISchema `json:"schemas" yaml:"schemas"`
+	} `json:"components" yaml:"components"`
+	Definitions map[string]openAPISchema `json:"definitions" yaml:"definitions"` // Swagger 2.0
+}
+
+type openAPIOperation struct {
+	OperationID string                     `json:"operationId" yaml:"operationId"`
+	Description string                     `json:"description" yaml:"description"`
+	Parameters  []openAPIParameter         `json:"parameters" yaml:"parameters"`
+	RequestBody *openAPIRequestBody        `json:"requestBody" yaml:"requestBody"`
+	Responses   map[string]openAPIResponse `json:"responses" yaml:"responses"`
+}
+
+type openAPIParameter struct {
+	Name        string        `json:"name" yaml:"name"`
+	Description string        `json:"description" yaml:"description"`
+	Schema      openAPISchema `json:"schema" yaml:"schema"`
+	// Type/Items are Swagger 2.0's way of typing a non-body parameter
+	// (in: query/path/header): unlike OpenAPI 3, it carries type/items
+	// directly on the parameter object instead of nesting them under schema.
+	Type  string         `json:"type" yaml:"type"`
+	Items *openAPISchema `json:"items" yaml:"items"`
+}
+
+// effectiveSchema returns param's type as an openAPISchema: Schema itself
+// for OpenAPI 3 (or a Swagger 2.0 body parameter), or one built from Type/
+// Items for a Swagger 2.0 non-body parameter, which doesn't populate Schema
+// at all.
+func (param openAPIParameter) effectiveSchema() openAPISchema {
+	if param.Schema.Type != "" || param.Schema.Ref != "" || len(param.Schema.Properties) != 0 {
+		return param.Schema
+	}
+	return openAPISchema{Type: param.Type, Items: param.Items}
+}
+
+type openAPIRequestBody struct {
+	Content map[string]openAPIMediaType `json:"content" yaml:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                       `json:"description" yaml:"description"`
+	Content     map[string]openAPIMediaType `json:"content" yaml:"content"`
+	Schema      openAPISchema                `json:"schema" yaml:"schema"` // Swagger 2.0
+}
+
+type openAPIMediaType struct {
+	Schema openAPISchema `json:"schema" yaml:"schema"`
+}
+
+type openAPISchema struct {
+	Ref        string                   `json:"$ref" yaml:"$ref"`
+	Type       string                   `json:"type" yaml:"type"`
+	Items      *openAPISchema           `json:"items" yaml:"items"`
+	Properties map[string]openAPISchema `json:"properties" yaml:"properties"`
+}
+
+// NewParserFromOpenAPI builds a Parser from an OpenAPI 3.0 or Swagger 2.0
+// document (detected by its path's extension: .yaml/.yml vs everything
+// else) instead of a Go source file. Paths, parameters, requestBody,
+// responses and components.schemas (or Swagger's definitions) are folded
+// into the same Entity/Parameter/Response model NewParser produces, so
+// every existing backend keeps working unmodified.
+func NewParserFromOpenAPI(path string) (*Parser, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc openAPIDocument
+	if ext := filepath.Ext(path); ext == ".yaml" || ext == ".yml" {
+		err = yaml.Unmarshal(raw, &doc)
+	} else {
+		err = json.Unmarshal(raw, &doc)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Parser{
+		Entities:         make([]*Entity, 0),
+		EntitiesByName:   make(map[string]*Entity),
+		backends:         make(map[string]Backend),
+		selectorMappings: selectorTypeMapper{},
+	}
+	if err := p.registerBuiltinTargets("../templates"); err != nil {
+		return nil, err
+	}
+
+	schemas := doc.Components.Schemas
+	if len(schemas) == 0 {
+		schemas = doc.Definitions
+	}
+
+	for _, route := range sortedKeys(doc.Paths) {
+		methods := doc.Paths[route]
+		for _, method := range sortedKeys(methods) {
+			p.addOpenAPIEntity(route, method, methods[method], schemas)
+		}
+	}
+
+	return p, nil
+}
+
+func (p *Parser) addOpenAPIEntity(route, method string, op openAPIOperation, schemas map[string]openAPISchema) {
+	name := op.OperationID
+	if name == "" {
+		name = toUpper(strings.ToLower(method)) + operationNameFromRoute(route)
+	}
+
+	entity := NewEntity(name)
+	entity.Description = op.Description
+
+	for _, param := range op.Parameters {
+		p := Parameter{
+			Field:       param.Name,
+			Description: param.Description,
+			Type:        mapOpenAPIType(param.effectiveSchema(), schemas),
+		}
+		entity.Parameters = append(entity.Parameters, p)
+		entity.ParameterByName[p.Field] = p
+	}
+
+	if op.RequestBody != nil {
+		for _, mediaType := range sortedKeys(op.RequestBody.Content) {
+			schema := resolveOpenAPISchema(op.RequestBody.Content[mediaType].Schema, schemas)
+			for _, field := range sortedKeys(schema.Properties) {
+				param := Parameter{
+					Field: field,
+					Type:  mapOpenAPIType(schema.Properties[field], schemas),
+				}
+				entity.Parameters = append(entity.Parameters, param)
+				entity.ParameterByName[param.Field] = param
+			}
+		}
+	}
+
+	if resp, ok := firstSuccessResponse(op.Responses); ok {
+		entity.Response = openAPIResponseFields(resp, schemas)
+	}
+
+	p.EntitiesByName[name] = entity
+	p.Entities = append(p.Entities, entity)
+}
+
+func firstSuccessResponse(responses map[string]openAPIResponse) (openAPIResponse, bool) {
+	for _, code := range []string{"200", "201"} {
+		if resp, ok := responses[code]; ok {
+			return resp, true
+		}
+	}
+	return openAPIResponse{}, false
+}
+
+func openAPIResponseFields(resp openAPIResponse, schemas map[string]openAPISchema) *Response {
+	schema := resp.Schema
+	if mediaTypes := sortedKeys(resp.Content); len(mediaTypes) > 0 {
+		schema = resp.Content[mediaTypes[0]].Schema
+	}
+	schema = resolveOpenAPISchema(schema, schemas)
+
+	if len(schema.Properties) == 0 {
+		return &Response{Type: mapOpenAPIType(schema, schemas)}
+	}
+
+	response := &Response{Type: "Object"}
+	for _, field := range sortedKeys(schema.Properties) {
+		response.Fields = append(response.Fields, ResponseField{
+			Field: field,
+			Type:  mapOpenAPIType(schema.Properties[field], schemas),
+		})
+	}
+	return response
+}
+
+func resolveOpenAPISchema(schema openAPISchema, schemas map[string]openAPISchema) openAPISchema {
+	if schema.Ref == "" {
+		return schema
+	}
+	name := schema.Ref[strings.LastIndex(schema.Ref, "/")+1:]
+	if resolved, ok := schemas[name]; ok {
+		return resolved
+	}
+	return schema
+}
+
+func mapOpenAPIType(schema openAPISchema, schemas map[string]openAPISchema) string {
+	if schema.Ref != "" {
+		return schema.Ref[strings.LastIndex(schema.Ref, "/")+1:]
+	}
+	switch schema.Type {
+	case "array":
+		if schema.Items != nil {
+			return "Array[" + mapOpenAPIType(*schema.Items, schemas) + "]"
+		}
+		return "Array"
+	case "integer":
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "object":
+		return "Object"
+	case "":
+		return "Object"
+	default:
+		return schema.Type
+	}
+}
+
+// operationNameFromRoute turns "/users/{id}/cards" into "UsersCards", used
+// as a fallback Entity name for operations with no operationId.
+func operationNameFromRoute(route string) string {
+	var b strings.Builder
+	for _, segment := range strings.Split(route, "/") {
+		if segment == "" || strings.HasPrefix(segment, "{") {
+			continue
+		}
+		b.WriteString(toUpper(segment))
+	}
+	return b.String()
+}
+
+// sortedKeys returns m's keys in sorted order, so iterating paths, methods,
+// media types and schema properties produces the same Entities (and the
+// same generated output) on every run, regardless of Go's randomized map
+// iteration order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,23 @@
+package parser
+
+import "testing"
+
+// TestResponseContentTypeDeterministic guards against regressing to ranging
+// resp.Content directly: a response declaring more than one content type
+// must resolve to the same schema (its lexicographically first media type)
+// on every run.
+func TestResponseContentTypeDeterministic(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		p, err := NewParserFromOpenAPI("testdata/openapi/multicontent.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		entity := p.EntitiesByName["GetUser"]
+		if entity == nil || entity.Response == nil || len(entity.Response.Fields) != 1 {
+			t.Fatalf("unexpected response: %+v", entity)
+		}
+		if entity.Response.Fields[0].Field != "name" {
+			t.Fatalf("expected deterministic pick of application/json's \"name\" field, got %q", entity.Response.Fields[0].Field)
+		}
+	}
+}
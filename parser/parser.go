@@ -3,13 +3,14 @@ package parser
 import (
 	"bytes"
 	"fmt"
-	"go/parser"
-	"go/token"
+	"go/ast"
+	"go/types"
 	"io/ioutil"
+	"regexp"
 	"strings"
 	"text/template"
-	"go/ast"
-	"regexp"
+
+	"golang.org/x/tools/go/packages"
 	"golang.org/x/tools/imports"
 )
 
@@ -44,55 +45,111 @@ type Response struct {
 
 func NewEntity(name string) *Entity {
 	return &Entity{
-		Name:        name,
-		Description: "",
-		Response:    nil,
+		Name:            name,
+		Description:     "",
+		Response:        nil,
+		ParameterByName: make(map[string]Parameter),
 	}
 }
 
 type Parser struct {
 	Entities		 		[]*Entity
 	EntitiesByName map[string]*Entity
-	file 					*ast.File
-	loggingServiceTemplate	*template.Template
+	files 					[]*ast.File
+	info					*types.Info
+	config					Config
+	serviceIface			*types.Interface
+	serviceMethods			map[string]bool
+	backends				map[string]Backend
+	typeMappers				[]TypeMapper
+	selectorMappings		selectorTypeMapper
 }
 
-func NewParser(name string) (*Parser, error) {
-	fs := token.NewFileSet()
-	f, err := parser.ParseFile(fs, name, nil, 0)
+// NewParser loads the whole Go package rooted at dir (not just one file),
+// so a Request/Response type can live in any file of the package and still
+// resolve correctly via go/types - including through type aliases and
+// embedded structs. cfg controls how endpoints and DTOs are recognized;
+// the zero Config matches this package's original "api" receiver /
+// Request/Response suffix convention.
+func NewParser(dir string, cfg Config) (*Parser, error) {
+	pkgCfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
+		Dir:  dir,
+	}
+	pkgs, err := packages.Load(pkgCfg, ".")
 	if err != nil {
 		return nil, err
 	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("no package found in %s", dir)
+	}
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return nil, fmt.Errorf("loading %s: %v", dir, pkg.Errors[0])
+	}
+
+	cfg = cfg.withDefaults()
+	serviceIface := resolveServiceInterface(pkg.Types, cfg.ServiceInterface)
+
+	p := &Parser{
+		Entities:       make([]*Entity, 0),
+		EntitiesByName: make(map[string]*Entity),
+		files:          pkg.Syntax,
+		info:           pkg.TypesInfo,
+		config:         cfg,
+		serviceIface:   serviceIface,
+		serviceMethods: serviceInterfaceMethodNames(serviceIface),
+		backends:       make(map[string]Backend),
+		selectorMappings: selectorTypeMapper{
+			"globalid.ID":         "UUID",
+			"model.ReactionType":  "string",
+			"model.CardsResponse": "Object",
+			"model.CardResponse":  "Object",
+			"model.Draft":         "Object",
+		},
+	}
 
 	loggingServiceTemplate, err := ioutil.ReadFile("../templates/logging-service.go.tmpl")
 	if err != nil {
 		return nil, err
 	}
+	p.RegisterBackend("logging-service", template.Must(template.New("logging-service").Parse(string(loggingServiceTemplate))))
 
-	return &Parser{
-		Entities:               make([]*Entity, 0),
-		file:                   f,
-		loggingServiceTemplate: template.Must(template.New("logging-service").Parse(string(loggingServiceTemplate))),
-	}, nil
+	return p, nil
 }
 
 func(p *Parser) Parse() error {
-	ast.Inspect(p.file, func(node ast.Node) bool {
-		switch x := node.(type) {
-		case *ast.TypeSpec:
-			if err := p.parseType(x); err != nil {
-				return false
+	var parseErr error
+	for _, f := range p.files {
+		ast.Inspect(f, func(node ast.Node) bool {
+			switch x := node.(type) {
+			case *ast.TypeSpec:
+				if err := p.parseType(x); err != nil {
+					parseErr = err
+					return false
+				}
+			case *ast.FuncDecl:
+				p.parseFunction(x)
 			}
-		case *ast.FuncDecl:
-			p.parseFunction(x)
+			return true
+		})
+		if parseErr != nil {
+			break
 		}
-		return true
-	})
+	}
+	if parseErr != nil {
+		return parseErr
+	}
 	return p.generate()
 }
 
 func (p *Parser) generate() error {
-	return p.generateCode(p.loggingServiceTemplate, "logging-service.go")
+	for name, backend := range p.backends {
+		if err := backend.Generate(p, name); err != nil {
+			return fmt.Errorf("backend %q: %w", name, err)
+		}
+	}
+	return nil
 }
 
 func (p *Parser) generateCode(tmpl *template.Template, fn string) error {
@@ -102,6 +159,9 @@ func (p *Parser) generateCode(tmpl *template.Template, fn string) error {
 	if err != nil {
 		return err
 	}
+	if !strings.HasSuffix(fn, ".go") {
+		return ioutil.WriteFile(fn, buf.Bytes(), 0666)
+	}
 	res, err := imports.Process(fn, buf.Bytes(), nil)
 	if err != nil {
 		return err
@@ -110,35 +170,49 @@ func (p *Parser) generateCode(tmpl *template.Template, fn string) error {
 }
 
 func (p *Parser) parseType(st *ast.TypeSpec) error {
-	if strings.HasSuffix(st.Name.Name, "Request") {
-		endpoint := strings.Replace(st.Name.Name, "Request", "", -1)
-		p.addParameter(endpoint, st.Type.(*ast.StructType))
+	if strings.HasSuffix(st.Name.Name, p.config.RequestSuffix) {
+		endpoint := strings.TrimSuffix(st.Name.Name, p.config.RequestSuffix)
+		return p.addParameter(endpoint, st.Type)
 	}
-	if strings.HasSuffix(st.Name.Name, "Response") {
-		endpoint := strings.Replace(st.Name.Name, "Response", "", -1)
-		p.addResponseField(endpoint, st.Type)
+	if strings.HasSuffix(st.Name.Name, p.config.ResponseSuffix) {
+		endpoint := strings.TrimSuffix(st.Name.Name, p.config.ResponseSuffix)
+		return p.addResponseField(endpoint, st.Type)
 	}
 	return nil
 }
 
-func (p *Parser) addParameter(entity string, st *ast.StructType) {
-	for _, field := range st.Fields.List {
-		params := Parameter{
-			Field:       field.Names[0].Name,
-			Description: field.Doc.Text(),
-			Tag:         parseTag(field.Tag.Value),
-			Type:        mapFieldType(field.Type),
+// addParameter collects entity's Request fields from expr, which is usually
+// a literal *ast.StructType but may instead be a type alias or named type
+// ("type GetUserRequest = innerRequest") - resolved via resolveNamedStruct
+// the same way an embedded field is.
+func (p *Parser) addParameter(entity string, expr ast.Expr) error {
+	var params []Parameter
+	switch x := expr.(type) {
+	case *ast.StructType:
+		var err error
+		params, err = p.collectParameters(x)
+		if err != nil {
+			return err
 		}
-
-		if p.EntitiesByName[entity] == nil {
-			p.EntitiesByName[entity] = NewEntity(entity)
+	default:
+		st, ok := p.resolveNamedStruct(expr)
+		if !ok {
+			return fmt.Errorf("%s%s: request type is neither a struct nor an alias to one", entity, p.config.RequestSuffix)
 		}
-		p.EntitiesByName[entity].Parameters = append(p.EntitiesByName[entity].Parameters, params)
-		p.EntitiesByName[entity].ParameterByName[params.Field] = params
+		params = typesStructParameters(st)
 	}
+
+	if p.EntitiesByName[entity] == nil {
+		p.EntitiesByName[entity] = NewEntity(entity)
+	}
+	for _, param := range params {
+		p.EntitiesByName[entity].Parameters = append(p.EntitiesByName[entity].Parameters, param)
+		p.EntitiesByName[entity].ParameterByName[param.Field] = param
+	}
+	return nil
 }
 
-func (p *Parser) addResponseField(entity string, expr ast.Expr) {
+func (p *Parser) addResponseField(entity string, expr ast.Expr) error {
 	if p.EntitiesByName[entity] == nil {
 		p.EntitiesByName[entity] = NewEntity(entity)
 	}
@@ -146,49 +220,29 @@ func (p *Parser) addResponseField(entity string, expr ast.Expr) {
 	switch x := expr.(type) {
 	case *ast.StructType:
 		response.Type = "Object"
-		response.Fields = make([]ResponseField, 0)
-		for _, field := range x.Fields.List {
-			responseField := ResponseField{
-				Description: field.Doc.Text(),
-				Field:       parseTag(field.Tag.Value),
-				Type:        mapFieldType(field.Type),
-			}
-			response.Fields = append(response.Fields, responseField)
+		params, err := p.collectParameters(x)
+		if err != nil {
+			return err
+		}
+		for _, param := range params {
+			response.Fields = append(response.Fields, ResponseField{
+				Field:       param.Field,
+				Description: param.Description,
+				Tag:         param.Tag,
+				Type:        param.Type,
+			})
 		}
 	default:
-		response.Type = mapFieldType(x)
+		t, err := p.mapFieldType(x)
+		if err != nil {
+			return err
+		}
+		response.Type = t
 	}
 	if response.Type != "Object" || len(response.Fields) != 0 {
 		p.EntitiesByName[entity].Response = response
 	}
-}
-
-func mapFieldType(expr ast.Expr) string {
-	switch x := expr.(type) {
-	case *ast.Ident:
-		return x.Name
-	case *ast.StarExpr:
-		ident, ok := x.X.(*ast.Ident)
-		if ok {
-			return ident.Name
-		}
-		return "Object"
-	case *ast.SelectorExpr:
-		name := fmt.Sprintf("%v.%s", x.X, x.Sel.Name)
-		switch name {
-		case "globalid.ID":
-			return "UUID"
-		case "model.ReactionType":
-			return "string"
-		case "model.CardsResponse", "model.CardResponse", "model.Draft":
-			return "Object"
-		}
-		return name
-	case *ast.ArrayType:
-		return "Array"
-	default:
-		panic(fmt.Sprintf("Unmapped type %T %v", x, x))
-	}
+	return nil
 }
 
 func parseTag(tag string) string {
@@ -200,16 +254,79 @@ func (p *Parser) parseFunction(fd *ast.FuncDecl) {
 	if fd.Recv == nil {
 		return
 	}
-	if recv, ok := fd.Recv.List[0].Type.(*ast.StarExpr); ok {
-		if ident, ok := recv.X.(*ast.Ident); ok {
-			name := fd.Name.Name
-			description := fd.Doc.Text()
-			firstChar := string(name[0])
-			if ident.Name == "api" && firstChar == strings.ToUpper(firstChar) {
-				p.AddEndpoint(name, description)
-			}
+
+	if p.config.EndpointFilter != nil {
+		if p.config.EndpointFilter(fd) {
+			p.AddEndpoint(fd.Name.Name, fd.Doc.Text())
+		}
+		return
+	}
+
+	name := fd.Name.Name
+	if !ast.IsExported(name) {
+		return
+	}
+
+	if p.serviceIface != nil {
+		if p.serviceMethods[name] && p.receiverImplementsServiceInterface(fd) {
+			p.AddEndpoint(name, fd.Doc.Text())
+		}
+		return
+	}
+
+	ident := receiverIdent(fd)
+	if ident == nil {
+		return
+	}
+	if p.receiverMatches(ident.Name) {
+		p.AddEndpoint(name, fd.Doc.Text())
+	}
+}
+
+// receiverIdent returns fd's receiver type name, unwrapping a pointer
+// receiver (func (s *api) ...) the same way a value receiver would be.
+func receiverIdent(fd *ast.FuncDecl) *ast.Ident {
+	switch t := fd.Recv.List[0].Type.(type) {
+	case *ast.StarExpr:
+		ident, _ := t.X.(*ast.Ident)
+		return ident
+	case *ast.Ident:
+		return t
+	default:
+		return nil
+	}
+}
+
+// receiverImplementsServiceInterface resolves fd's receiver to the *types.Named
+// type go/types recorded for it and checks, via types.Implements, that the
+// receiver (or a pointer to it) actually satisfies p.serviceIface - as
+// opposed to merely declaring a method with a matching name.
+func (p *Parser) receiverImplementsServiceInterface(fd *ast.FuncDecl) bool {
+	ident := receiverIdent(fd)
+	if ident == nil {
+		return false
+	}
+	obj := p.info.Uses[ident]
+	if obj == nil {
+		return false
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return false
+	}
+	return types.Implements(named, p.serviceIface) || types.Implements(types.NewPointer(named), p.serviceIface)
+}
+
+func (p *Parser) receiverMatches(name string) bool {
+	if p.config.ReceiverPattern != nil {
+		return p.config.ReceiverPattern.MatchString(name)
+	}
+	for _, r := range p.config.ReceiverNames {
+		if r == name {
+			return true
 		}
 	}
+	return false
 }
 
 func (p *Parser) AddEndpoint(name, description string) {
@@ -0,0 +1,145 @@
+package parser
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"text/template"
+
+	"github.com/emicklei/proto"
+)
+
+// NewParserFromProto builds a Parser from a .proto service definition
+// instead of a Go source file. Each rpc becomes an Entity; its request and
+// response messages are resolved (repeated fields, map fields, enums, fields
+// typed by a message declared in the file, which keep that message's name,
+// and google.protobuf.Timestamp; other well-known types become "Object")
+// into the same Parameter/Response model NewParser produces, so logging-service.go.tmpl
+// renders the same output for a gRPC server whose types are generated from
+// the .proto file rather than hand-written.
+func NewParserFromProto(path string) (*Parser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	def, err := proto.NewParser(f).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	messages := map[string]*proto.Message{}
+	proto.Walk(def, proto.WithMessage(func(m *proto.Message) {
+		messages[m.Name] = m
+	}))
+
+	enums := map[string]bool{}
+	proto.Walk(def, proto.WithEnum(func(e *proto.Enum) {
+		enums[e.Name] = true
+	}))
+
+	loggingServiceTemplate, err := ioutil.ReadFile("../templates/logging-service.go.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Parser{
+		Entities:         make([]*Entity, 0),
+		EntitiesByName:   make(map[string]*Entity),
+		backends:         make(map[string]Backend),
+		selectorMappings: selectorTypeMapper{},
+	}
+	p.RegisterBackend("logging-service", template.Must(template.New("logging-service").Parse(string(loggingServiceTemplate))))
+
+	proto.Walk(def, proto.WithService(func(s *proto.Service) {
+		for _, elem := range s.Elements {
+			if rpc, ok := elem.(*proto.RPC); ok {
+				p.addProtoEntity(rpc, messages, enums)
+			}
+		}
+	}))
+
+	return p, nil
+}
+
+func (p *Parser) addProtoEntity(rpc *proto.RPC, messages map[string]*proto.Message, enums map[string]bool) {
+	entity := NewEntity(rpc.Name)
+
+	if msg, ok := messages[rpc.RequestType]; ok {
+		for _, field := range protoFields(msg, messages, enums) {
+			param := Parameter{
+				Field: field.Name,
+				Type:  field.Type,
+			}
+			entity.Parameters = append(entity.Parameters, param)
+			entity.ParameterByName[param.Field] = param
+		}
+	}
+
+	if msg, ok := messages[rpc.ReturnsType]; ok {
+		response := &Response{Type: "Object"}
+		for _, field := range protoFields(msg, messages, enums) {
+			response.Fields = append(response.Fields, ResponseField{
+				Field: field.Name,
+				Type:  field.Type,
+			})
+		}
+		entity.Response = response
+	}
+
+	p.EntitiesByName[rpc.Name] = entity
+	p.Entities = append(p.Entities, entity)
+}
+
+// protoField is a resolved name/type pair for one field of a message,
+// covering both the plain (*proto.NormalField) and map (*proto.MapField)
+// shapes the emicklei/proto library returns - msg.Elements also holds
+// nested message/enum *declarations*, which aren't fields and are skipped.
+type protoField struct {
+	Name string
+	Type string
+}
+
+func protoFields(msg *proto.Message, messages map[string]*proto.Message, enums map[string]bool) []protoField {
+	var fields []protoField
+	for _, elem := range msg.Elements {
+		switch f := elem.(type) {
+		case *proto.NormalField:
+			elemType := mapProtoScalar(f.Type, messages, enums)
+			if f.Repeated {
+				elemType = fmt.Sprintf("Array[%s]", elemType)
+			}
+			fields = append(fields, protoField{Name: f.Name, Type: elemType})
+		case *proto.MapField:
+			key := mapProtoScalar(f.KeyType, messages, enums)
+			value := mapProtoScalar(f.Type, messages, enums)
+			fields = append(fields, protoField{Name: f.Name, Type: fmt.Sprintf("map[%s]%s", key, value)})
+		}
+	}
+	return fields
+}
+
+func mapProtoScalar(typeName string, messages map[string]*proto.Message, enums map[string]bool) string {
+	switch typeName {
+	case "string":
+		return "string"
+	case "int32", "int64", "uint32", "uint64", "sint32", "sint64", "fixed32", "fixed64", "sfixed32", "sfixed64":
+		return "int"
+	case "bool":
+		return "bool"
+	case "float", "double":
+		return "float64"
+	case "bytes":
+		return "[]byte"
+	case "google.protobuf.Timestamp":
+		return "time.Time"
+	}
+	if enums[typeName] {
+		return "string"
+	}
+	if _, ok := messages[typeName]; ok {
+		return typeName
+	}
+	return "Object"
+}
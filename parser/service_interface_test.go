@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestServiceInterfaceRequiresRealSatisfaction guards against regressing to
+// name-only matching: Config.ServiceInterface must only pick up methods
+// whose receiver actually implements the interface, not any exported method
+// that happens to share a name with one of its methods.
+func TestServiceInterfaceRequiresRealSatisfaction(t *testing.T) {
+	p, err := NewParser("testdata/serviceiface", Config{ServiceInterface: "Svc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	if err := os.Chdir(outDir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	if err := p.Parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	entity := p.EntitiesByName["GetUser"]
+	if entity == nil {
+		t.Fatal("expected GetUser endpoint to be discovered")
+	}
+	if want := "Is the real endpoint implementing Svc.\n"; entity.Description != want {
+		t.Fatalf("endpoint doc was overwritten by the non-implementing same-named method: got %q, want %q", entity.Description, want)
+	}
+
+	if _, err := os.Stat(filepath.Join(outDir, "logging-service.go")); err != nil {
+		t.Fatal(err)
+	}
+}
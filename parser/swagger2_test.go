@@ -0,0 +1,28 @@
+package parser
+
+import "testing"
+
+// TestSwagger2NonBodyParameterTypes guards against regressing to reading
+// only param.Schema: a Swagger 2.0 non-body parameter (in: query/path/
+// header) carries type/items directly on the parameter object, not nested
+// under schema, and must still resolve to a real type instead of "Object".
+func TestSwagger2NonBodyParameterTypes(t *testing.T) {
+	p, err := NewParserFromOpenAPI("testdata/openapi/swagger2-params.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	entity := p.EntitiesByName["GetUser"]
+	if entity == nil {
+		t.Fatal("expected GetUser entity")
+	}
+	got := map[string]string{}
+	for _, param := range entity.Parameters {
+		got[param.Field] = param.Type
+	}
+	if got["id"] != "string" {
+		t.Fatalf("path parameter type: got %q, want string", got["id"])
+	}
+	if got["limit"] != "int" {
+		t.Fatalf("query parameter type: got %q, want int", got["limit"])
+	}
+}
@@ -0,0 +1,20 @@
+package aliasrequest
+
+type api struct{}
+
+func (a *api) GetUser(req GetUserRequest) (GetUserResponse, error) {
+	return GetUserResponse{}, nil
+}
+
+// innerRequest is the real struct; GetUserRequest is a type alias to it, so
+// parseType's *ast.TypeSpec for GetUserRequest has an *ast.Ident Type, not
+// an *ast.StructType.
+type innerRequest struct {
+	ID string `json:"id"`
+}
+
+type GetUserRequest = innerRequest
+
+type GetUserResponse struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,15 @@
+package badrequest
+
+type api struct{}
+
+func (a *api) GetUser(req GetUserRequest) (GetUserResponse, error) {
+	return GetUserResponse{}, nil
+}
+
+// GetUserRequest is neither a struct literal nor an alias to one, so
+// parseType/addParameter must return an error instead of panicking.
+type GetUserRequest string
+
+type GetUserResponse struct {
+	Name string `json:"name"`
+}
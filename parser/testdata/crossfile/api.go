@@ -0,0 +1,10 @@
+package crossfile
+
+type api struct{}
+
+// GetUser is the endpoint under test; its Request/Response types live in
+// a separate file to exercise NewParser's whole-package (not single-file)
+// resolution.
+func (a *api) GetUser(req GetUserRequest) (GetUserResponse, error) {
+	return GetUserResponse{}, nil
+}
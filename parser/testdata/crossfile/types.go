@@ -0,0 +1,17 @@
+package crossfile
+
+// Pagination is embedded into GetUserRequest to exercise expanding an
+// anonymous (embedded) field into its own Parameters.
+type Pagination struct {
+	Page     int `json:"page"`
+	PageSize int `json:"page_size"`
+}
+
+type GetUserRequest struct {
+	Pagination
+	ID string `json:"id"`
+}
+
+type GetUserResponse struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,16 @@
+package maptypes
+
+type api struct{}
+
+func (a *api) GetUser(req GetUserRequest) (GetUserResponse, error) {
+	return GetUserResponse{}, nil
+}
+
+type GetUserRequest struct {
+	Filters map[string]string `json:"filters"`
+	Tags    []string          `json:"tags"`
+}
+
+type GetUserResponse struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,27 @@
+package serviceiface
+
+// Svc is the interface NewParser is configured to discover endpoints from
+// in TestServiceInterfaceRequiresRealSatisfaction.
+type Svc interface {
+	GetUser(id string) (UserResponse, error)
+}
+
+type api struct{}
+
+// GetUser is the real endpoint implementing Svc.
+func (a *api) GetUser(id string) (UserResponse, error) {
+	return UserResponse{}, nil
+}
+
+type UserResponse struct {
+	Name string `json:"name"`
+}
+
+// other declares a method with the same name as Svc.GetUser but a different
+// signature, so it does not implement Svc and must not be mistaken for an
+// endpoint.
+type other struct{}
+
+func (o *other) GetUser(id string) (string, error) {
+	return "", nil
+}
@@ -0,0 +1,95 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+)
+
+// TypeMapper turns a Go AST type expression into the string the templates
+// render (e.g. "UUID", "[]string"). Map returns ok=false when it doesn't
+// recognize expr, letting Parser fall through to the next registered
+// mapper.
+type TypeMapper interface {
+	Map(expr ast.Expr) (string, bool)
+}
+
+// selectorTypeMapper resolves "pkg.Name" selector expressions registered via
+// RegisterTypeMapping, e.g. "globalid.ID" -> "UUID".
+type selectorTypeMapper map[string]string
+
+func (m selectorTypeMapper) Map(expr ast.Expr) (string, bool) {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+	target, ok := m[fmt.Sprintf("%v.%s", sel.X, sel.Sel.Name)]
+	return target, ok
+}
+
+// defaultTypeMapper covers the shapes every project hits: builtin idents,
+// pointers, arrays and maps (emitted as real Go syntax so the generated
+// struct field actually compiles), and bare selectors. It's always
+// consulted last, after any mapper a caller registered.
+type defaultTypeMapper struct{}
+
+func (defaultTypeMapper) Map(expr ast.Expr) (string, bool) {
+	switch x := expr.(type) {
+	case *ast.Ident:
+		return x.Name, true
+	case *ast.StarExpr:
+		return defaultTypeMapper{}.Map(x.X)
+	case *ast.ArrayType:
+		elem, ok := defaultTypeMapper{}.Map(x.Elt)
+		if !ok {
+			elem = "Object"
+		}
+		return "[]" + elem, true
+	case *ast.MapType:
+		key, ok := defaultTypeMapper{}.Map(x.Key)
+		if !ok {
+			key = "Object"
+		}
+		value, ok := defaultTypeMapper{}.Map(x.Value)
+		if !ok {
+			value = "Object"
+		}
+		return fmt.Sprintf("map[%s]%s", key, value), true
+	case *ast.SelectorExpr:
+		return fmt.Sprintf("%v.%s", x.X, x.Sel.Name), true
+	default:
+		return "", false
+	}
+}
+
+// RegisterTypeMapping registers a single "pkg.Name" -> target mapping,
+// e.g. RegisterTypeMapping("globalid.ID", "UUID"). Selector mappings are
+// consulted after every mapper added via RegisterTypeMapper and before the
+// default mapper.
+func (p *Parser) RegisterTypeMapping(selector, target string) {
+	p.selectorMappings[selector] = target
+}
+
+// RegisterTypeMapper adds tm ahead of the built-in selector and default
+// mappers, so project-specific rules (e.g. every *ast.SelectorExpr under
+// "model" becomes "Object") can be tried first.
+func (p *Parser) RegisterTypeMapper(tm TypeMapper) {
+	p.typeMappers = append([]TypeMapper{tm}, p.typeMappers...)
+}
+
+// mapFieldType replaces the old package-level mapFieldType switch: it walks
+// the registered mappers in order and returns an error instead of panicking
+// when none of them recognize expr.
+func (p *Parser) mapFieldType(expr ast.Expr) (string, error) {
+	for _, tm := range p.typeMappers {
+		if t, ok := tm.Map(expr); ok {
+			return t, nil
+		}
+	}
+	if t, ok := p.selectorMappings.Map(expr); ok {
+		return t, nil
+	}
+	if t, ok := (defaultTypeMapper{}).Map(expr); ok {
+		return t, nil
+	}
+	return "", fmt.Errorf("unmapped type %T %v", expr, expr)
+}
@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"testing"
+)
+
+// TestMapAndSliceFieldsProduceValidGo guards against defaultTypeMapper
+// regressing to non-Go syntax like "Array[string]"/"Map[K]V": a map or
+// slice Request field must render as a struct field that actually compiles.
+func TestMapAndSliceFieldsProduceValidGo(t *testing.T) {
+	p, err := NewParser("testdata/maptypes", Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	if err := os.Chdir(outDir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	if err := p.Parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	if _, err := parser.ParseFile(fset, "logging-service.go", nil, 0); err != nil {
+		t.Fatalf("generated logging-service.go is not valid Go: %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"go/ast"
+	goparser "go/parser"
+	"testing"
+)
+
+func TestDefaultTypeMapper(t *testing.T) {
+	cases := map[string]string{
+		"string":         "string",
+		"*User":          "User",
+		"[]string":       "[]string",
+		"map[string]int": "map[string]int",
+		"model.Draft":    "model.Draft",
+	}
+	for src, want := range cases {
+		expr, err := goparser.ParseExpr(src)
+		if err != nil {
+			t.Fatalf("parsing %q: %v", src, err)
+		}
+		got, ok := (defaultTypeMapper{}).Map(expr)
+		if !ok {
+			t.Fatalf("%q: expected a match", src)
+		}
+		if got != want {
+			t.Fatalf("%q: got %q, want %q", src, got, want)
+		}
+	}
+}
+
+func TestDefaultTypeMapperUnrecognized(t *testing.T) {
+	expr, err := goparser.ParseExpr("func()")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := (defaultTypeMapper{}).Map(expr); ok {
+		t.Fatal("expected func types to be unrecognized")
+	}
+}
+
+func TestMapFieldTypeUsesSelectorMappings(t *testing.T) {
+	p := &Parser{selectorMappings: selectorTypeMapper{}}
+	p.RegisterTypeMapping("globalid.ID", "UUID")
+
+	expr, err := goparser.ParseExpr("globalid.ID")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := p.mapFieldType(expr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "UUID" {
+		t.Fatalf("got %q, want UUID", got)
+	}
+}
+
+// typeMapperFunc adapts a plain function to the TypeMapper interface, so
+// tests can register a one-off mapper without declaring a named type.
+type typeMapperFunc func(expr ast.Expr) (string, bool)
+
+func (f typeMapperFunc) Map(expr ast.Expr) (string, bool) { return f(expr) }
+
+func TestRegisterTypeMapperTakesPriority(t *testing.T) {
+	p := &Parser{selectorMappings: selectorTypeMapper{"globalid.ID": "UUID"}}
+
+	calls := 0
+	p.RegisterTypeMapper(typeMapperFunc(func(expr ast.Expr) (string, bool) {
+		calls++
+		return "Overridden", true
+	}))
+
+	expr, err := goparser.ParseExpr("globalid.ID")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := p.mapFieldType(expr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "Overridden" {
+		t.Fatalf("got %q, want Overridden (custom mapper should run before selectorMappings)", got)
+	}
+	if calls != 1 {
+		t.Fatalf("expected custom mapper to be consulted once, got %d", calls)
+	}
+}
+
+func TestMapFieldTypeReturnsErrorWhenUnmapped(t *testing.T) {
+	p := &Parser{selectorMappings: selectorTypeMapper{}}
+	expr, err := goparser.ParseExpr("func()")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.mapFieldType(expr); err == nil {
+		t.Fatal("expected an error for an unmapped type")
+	}
+}